@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/tkschmidt/fischertermine/geocode"
+	"github.com/tkschmidt/fischertermine/pkg/fischertermine"
+)
+
+// enrichWithCoordinates resolves each exam's address to coordinates via
+// geocoder, recording them on the exam. If near is non-nil, each exam's
+// great-circle distance from near is recorded too. Geocoding failures are
+// logged and otherwise ignored: an exam without coordinates still prints,
+// it just can't be sorted or filtered by distance.
+func enrichWithCoordinates(ctx context.Context, exams []fischertermine.ExamAppointment, geocoder geocode.Geocoder, near *geocode.Coordinates) {
+	for i := range exams {
+		exam := &exams[i]
+
+		address := strings.TrimSpace(strings.Join([]string{
+			strings.TrimSpace(exam.Street + " " + exam.HouseNumber),
+			strings.TrimSpace(exam.PostalCode + " " + exam.City),
+		}, ", "))
+		address = strings.Trim(address, ", ")
+		if address == "" {
+			continue
+		}
+
+		lat, lon, err := geocoder.Geocode(ctx, address)
+		if err != nil {
+			log.Printf("Warning: could not geocode %q: %v", address, err)
+			continue
+		}
+		exam.Latitude = lat
+		exam.Longitude = lon
+
+		if near != nil {
+			exam.DistanceKm = geocode.HaversineKm(near.Lat, near.Lon, lat, lon)
+		}
+	}
+}
+
+// hasCoordinates reports whether an exam was successfully geocoded. (0,0)
+// is open ocean, not a Bavarian exam venue, so it doubles as "not set".
+func hasCoordinates(exam fischertermine.ExamAppointment) bool {
+	return exam.Latitude != 0 || exam.Longitude != 0
+}
+
+// filterByRadius keeps only geocoded exams within radiusKm of near,
+// preserving order.
+func filterByRadius(exams []fischertermine.ExamAppointment, radiusKm float64) []fischertermine.ExamAppointment {
+	filtered := exams[:0]
+	for _, exam := range exams {
+		if hasCoordinates(exam) && exam.DistanceKm <= radiusKm {
+			filtered = append(filtered, exam)
+		}
+	}
+	return filtered
+}
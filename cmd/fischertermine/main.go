@@ -0,0 +1,256 @@
+// Command fischertermine scrapes the Bavarian Fischerprüfung booking system
+// for open exam appointments and prints them as JSON or an iCalendar feed.
+// The "serve" subcommand instead runs a small HTTP server that keeps a
+// cached snapshot up to date in the background.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tkschmidt/fischertermine/geocode"
+	"github.com/tkschmidt/fischertermine/notify"
+	"github.com/tkschmidt/fischertermine/pkg/fischertermine"
+	"github.com/tkschmidt/fischertermine/store"
+)
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		runServe(os.Args[2:])
+		return
+	}
+
+	format := flag.String("format", "json", "output format: json or ics")
+	icsDuration := flag.Duration("ics-duration", 90*time.Minute, "VEVENT duration to assume when exporting --format ics")
+	concurrency := flag.Int("concurrency", 20, "number of workers queued to fetch exam detail pages (rate-limited and serialized against the shared session internally, so this can safely exceed 10)")
+	watch := flag.Duration("watch", 0, "if set, re-run the scraper on this interval instead of exiting after one run")
+	storePath := flag.String("store", "fischertermine.state.json", "path to the snapshot file used to detect changes in --watch mode")
+	telegramToken := flag.String("telegram-token", os.Getenv("TELEGRAM_BOT_TOKEN"), "Telegram bot token for --watch notifications (env TELEGRAM_BOT_TOKEN)")
+	telegramChatID := flag.String("telegram-chat-id", os.Getenv("TELEGRAM_CHAT_ID"), "Telegram chat id for --watch notifications (env TELEGRAM_CHAT_ID)")
+	webhookURL := flag.String("webhook-url", os.Getenv("NOTIFY_WEBHOOK_URL"), "webhook URL to POST change events to in --watch mode (env NOTIFY_WEBHOOK_URL)")
+	smtpAddr := flag.String("smtp-addr", os.Getenv("SMTP_ADDR"), "SMTP server host:port for --watch email notifications (env SMTP_ADDR)")
+	smtpUsername := flag.String("smtp-username", os.Getenv("SMTP_USERNAME"), "SMTP auth username (env SMTP_USERNAME)")
+	smtpPassword := flag.String("smtp-password", os.Getenv("SMTP_PASSWORD"), "SMTP auth password (env SMTP_PASSWORD)")
+	smtpFrom := flag.String("smtp-from", os.Getenv("SMTP_FROM"), "From address for --watch email notifications (env SMTP_FROM)")
+	smtpTo := flag.String("smtp-to", os.Getenv("SMTP_TO"), "comma-separated To addresses for --watch email notifications (env SMTP_TO)")
+	doGeocode := flag.Bool("geocode", false, "resolve each exam's address to latitude/longitude")
+	geocoderBackend := flag.String("geocoder", "nominatim", "geocoding backend to use: nominatim or photon")
+	geocodeCachePath := flag.String("geocode-cache", "geocode.cache.json", "path to the on-disk geocoding cache")
+	near := flag.String("near", "", "lat,lon to measure exam distance from; implies --geocode")
+	radiusKm := flag.Float64("radius-km", 0, "with --near, drop exams farther than this many km away")
+	sortBy := flag.String("sort-by", "", "additional sort applied after the default date/location/status sort: distance (requires --near)")
+	flag.Parse()
+
+	if *format != "json" && *format != "ics" {
+		log.Fatalf("Error: unknown --format %q (want json or ics)", *format)
+	}
+
+	var nearCoord *geocode.Coordinates
+	if *near != "" {
+		coord, err := parseCoordinates(*near)
+		if err != nil {
+			log.Fatalf("Error parsing --near: %v", err)
+		}
+		nearCoord = &coord
+		*doGeocode = true
+	}
+	if *sortBy == "distance" && nearCoord == nil {
+		log.Fatal("Error: --sort-by distance requires --near")
+	}
+
+	var geo geocode.Geocoder
+	if *doGeocode {
+		g, err := newGeocoder(*geocoderBackend, *geocodeCachePath)
+		if err != nil {
+			log.Fatalf("Error setting up geocoder: %v", err)
+		}
+		geo = g
+	}
+
+	var sinks []notify.Sink
+	if *telegramToken != "" && *telegramChatID != "" {
+		sinks = append(sinks, notify.NewTelegramSink(*telegramToken, *telegramChatID))
+	}
+	if *webhookURL != "" {
+		sinks = append(sinks, notify.NewWebhookSink(*webhookURL))
+	}
+	if *smtpAddr != "" && *smtpFrom != "" && *smtpTo != "" {
+		sinks = append(sinks, notify.NewSMTPSink(*smtpAddr, *smtpUsername, *smtpPassword, smtpAuthHost(*smtpAddr), *smtpFrom, splitAndTrim(*smtpTo)))
+	}
+
+	client := fischertermine.NewClient()
+	ctx := context.Background()
+
+	if *watch <= 0 {
+		exams, err := scrape(ctx, client, *concurrency, geo, nearCoord, *radiusKm, *sortBy)
+		if err != nil {
+			log.Fatal("Error scraping exams:", err)
+		}
+		printExams(exams, *format, *icsDuration)
+		return
+	}
+
+	runWatch(ctx, client, *concurrency, *watch, store.New(*storePath), sinks, *format, *icsDuration, geo, nearCoord, *radiusKm, *sortBy)
+}
+
+// smtpAuthHost returns the hostname PLAIN auth should identify itself
+// against, derived from an "smtp-addr" flag of the form host:port.
+func smtpAuthHost(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+// splitAndTrim splits a comma-separated flag value into its trimmed parts.
+func splitAndTrim(s string) []string {
+	parts := strings.Split(s, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// parseCoordinates parses a "lat,lon" flag value.
+func parseCoordinates(s string) (geocode.Coordinates, error) {
+	parts := strings.SplitN(s, ",", 2)
+	if len(parts) != 2 {
+		return geocode.Coordinates{}, fmt.Errorf("want \"lat,lon\", got %q", s)
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return geocode.Coordinates{}, fmt.Errorf("parsing latitude: %w", err)
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return geocode.Coordinates{}, fmt.Errorf("parsing longitude: %w", err)
+	}
+	return geocode.Coordinates{Lat: lat, Lon: lon}, nil
+}
+
+// newGeocoder builds the requested geocoding backend, wrapped in an
+// on-disk cache that also satisfies the backend's rate policy.
+func newGeocoder(backend, cachePath string) (geocode.Geocoder, error) {
+	var inner geocode.Geocoder
+	switch backend {
+	case "nominatim":
+		inner = geocode.NewNominatimGeocoder("fischertermine (+https://github.com/tkschmidt/fischertermine)")
+	case "photon":
+		inner = geocode.NewPhotonGeocoder()
+	default:
+		return nil, fmt.Errorf("unknown --geocoder %q (want nominatim or photon)", backend)
+	}
+	return geocode.NewCache(inner, cachePath, time.Second)
+}
+
+// scrape lists all exams, fetches their details, and applies any requested
+// geocoding, distance filtering and distance sorting.
+func scrape(ctx context.Context, client *fischertermine.Client, concurrency int, geocoder geocode.Geocoder, near *geocode.Coordinates, radiusKm float64, sortBy string) ([]fischertermine.ExamAppointment, error) {
+	log.Println("Fetching exams...")
+	exams, err := client.ListAll(ctx, concurrency)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Fetched %d exams\n", len(exams))
+
+	if geocoder != nil {
+		enrichWithCoordinates(ctx, exams, geocoder, near)
+	}
+	if near != nil && radiusKm > 0 {
+		exams = filterByRadius(exams, radiusKm)
+	}
+	if sortBy == "distance" {
+		sort.SliceStable(exams, func(i, j int) bool { return exams[i].DistanceKm < exams[j].DistanceKm })
+	}
+
+	return exams, nil
+}
+
+// printExams writes exams to stdout in the requested format.
+func printExams(exams []fischertermine.ExamAppointment, format string, icsDuration time.Duration) {
+	if format == "ics" {
+		fmt.Print(fischertermine.BuildICS(exams, icsDuration, time.Now()))
+		return
+	}
+
+	output := fischertermine.OutputData{
+		ExamAppointments: exams,
+		TotalCount:       len(exams),
+	}
+
+	jsonOutput, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		log.Fatal("Error creating JSON:", err)
+	}
+
+	fmt.Print(string(jsonOutput))
+}
+
+// runWatch runs the scraper on a jittered interval until the process is
+// killed, diffing each run against the last persisted snapshot and notifying
+// sinks about anything that changed.
+func runWatch(ctx context.Context, client *fischertermine.Client, concurrency int, interval time.Duration, snapStore *store.Store, sinks []notify.Sink, format string, icsDuration time.Duration, geocoder geocode.Geocoder, near *geocode.Coordinates, radiusKm float64, sortBy string) {
+	for {
+		exams, err := scrape(ctx, client, concurrency, geocoder, near, radiusKm, sortBy)
+		if err != nil {
+			log.Printf("Error scraping exams: %v", err)
+		} else {
+			printExams(exams, format, icsDuration)
+			notifyChanges(ctx, snapStore, exams, sinks)
+		}
+
+		jitter := time.Duration(rand.Int63n(int64(interval) / 5))
+		sleep := interval - jitter/2 + time.Duration(rand.Int63n(int64(jitter)+1))
+		log.Printf("Sleeping %s until next scrape", sleep)
+		time.Sleep(sleep)
+	}
+}
+
+// notifyChanges diffs exams against the last persisted snapshot, saves the
+// new snapshot, and delivers any detected changes to sinks.
+func notifyChanges(ctx context.Context, snapStore *store.Store, exams []fischertermine.ExamAppointment, sinks []notify.Sink) {
+	prev, err := snapStore.Load()
+	if err != nil {
+		log.Printf("Error loading snapshot: %v", err)
+		prev = store.Snapshot{Exams: map[string]store.ExamSnapshot{}}
+	}
+
+	next := store.Snapshot{Exams: make(map[string]store.ExamSnapshot, len(exams))}
+	for _, exam := range exams {
+		next.Exams[fischertermine.UID(exam)] = store.ExamSnapshot{
+			DateTime:            exam.DateTime,
+			Location:            exam.Location,
+			Status:              exam.Status,
+			DetailStatus:        exam.DetailStatus,
+			CurrentParticipants: exam.CurrentParticipants,
+		}
+	}
+
+	changes := store.Diff(prev, next)
+	if len(changes) > 0 {
+		events := notify.EventsFromChanges(changes)
+		for _, sink := range sinks {
+			if err := sink.Notify(ctx, events); err != nil {
+				log.Printf("Error notifying sink: %v", err)
+			}
+		}
+	}
+
+	if err := snapStore.Save(next); err != nil {
+		log.Printf("Error saving snapshot: %v", err)
+	}
+}
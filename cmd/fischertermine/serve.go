@@ -0,0 +1,310 @@
+package main
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tkschmidt/fischertermine/geocode"
+	"github.com/tkschmidt/fischertermine/pkg/fischertermine"
+)
+
+// cacheMaxAge is how long clients and proxies may cache a response before
+// revalidating, independent of how often the background scraper itself runs.
+const cacheMaxAge = 60 * time.Second
+
+// snapshotCache holds the most recently scraped exams, pre-rendered as JSON
+// and ICS, plus the Last-Modified/ETag pair every handler validates against.
+// Readers take the RLock; the scrape loop takes the write lock once per run.
+type snapshotCache struct {
+	mu sync.RWMutex
+
+	exams        []fischertermine.ExamAppointment
+	json         []byte
+	ics          []byte
+	etag         string
+	lastModified time.Time
+	ready        bool
+}
+
+func (c *snapshotCache) update(exams []fischertermine.ExamAppointment, icsDuration time.Duration, at time.Time) {
+	output := fischertermine.OutputData{ExamAppointments: exams, TotalCount: len(exams)}
+	jsonBytes, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		log.Printf("Error marshaling exams for cache: %v", err)
+		return
+	}
+	icsBytes := []byte(fischertermine.BuildICS(exams, icsDuration, at))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.exams = exams
+	c.json = jsonBytes
+	c.ics = icsBytes
+	c.etag = fmt.Sprintf("%x", sha1.Sum(jsonBytes))
+	c.lastModified = at
+	c.ready = true
+}
+
+func (c *snapshotCache) snapshot() (exams []fischertermine.ExamAppointment, jsonBytes, icsBytes []byte, etag string, lastModified time.Time, ready bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.exams, c.json, c.ics, c.etag, c.lastModified, c.ready
+}
+
+// serveMetrics tracks Prometheus-style counters for the scrape loop.
+type serveMetrics struct {
+	mu            sync.Mutex
+	scrapesOK     int64
+	scrapesFailed int64
+	examCount     int64
+	lastDuration  time.Duration
+}
+
+func (m *serveMetrics) recordSuccess(examCount int, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.scrapesOK++
+	m.examCount = int64(examCount)
+	m.lastDuration = d
+}
+
+func (m *serveMetrics) recordFailure(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.scrapesFailed++
+	m.lastDuration = d
+}
+
+func (m *serveMetrics) render() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var b strings.Builder
+	fmt.Fprintf(&b, "# HELP fischertermine_scrapes_total Number of scrape attempts by result.\n")
+	fmt.Fprintf(&b, "# TYPE fischertermine_scrapes_total counter\n")
+	fmt.Fprintf(&b, "fischertermine_scrapes_total{result=\"success\"} %d\n", m.scrapesOK)
+	fmt.Fprintf(&b, "fischertermine_scrapes_total{result=\"failure\"} %d\n", m.scrapesFailed)
+	fmt.Fprintf(&b, "# HELP fischertermine_exam_count Number of exams in the last successful scrape.\n")
+	fmt.Fprintf(&b, "# TYPE fischertermine_exam_count gauge\n")
+	fmt.Fprintf(&b, "fischertermine_exam_count %d\n", m.examCount)
+	fmt.Fprintf(&b, "# HELP fischertermine_last_scrape_duration_seconds Duration of the last scrape, success or failure.\n")
+	fmt.Fprintf(&b, "# TYPE fischertermine_last_scrape_duration_seconds gauge\n")
+	fmt.Fprintf(&b, "fischertermine_last_scrape_duration_seconds %f\n", m.lastDuration.Seconds())
+	return b.String()
+}
+
+// indexTemplate renders the cached exams as an HTML table, applying
+// whatever region/city/status filters were passed in the request.
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html lang="de">
+<head><meta charset="utf-8"><title>Fischertermine</title></head>
+<body>
+<h1>Fischertermine</h1>
+<form method="get">
+  <input type="text" name="region" placeholder="Region" value="{{.Region}}">
+  <input type="text" name="city" placeholder="Ort" value="{{.City}}">
+  <input type="text" name="status" placeholder="Status" value="{{.Status}}">
+  <button type="submit">Filtern</button>
+</form>
+<p>{{len .Exams}} von {{.Total}} Terminen</p>
+<table border="1" cellpadding="4">
+<tr><th>Datum</th><th>Ort</th><th>Region</th><th>Status</th></tr>
+{{range .Exams}}<tr><td>{{.DateTime}}</td><td>{{.Location}}</td><td>{{.Region}}</td><td>{{.Status}}</td></tr>
+{{end}}
+</table>
+</body>
+</html>
+`))
+
+type indexPage struct {
+	Exams  []fischertermine.ExamAppointment
+	Total  int
+	Region string
+	City   string
+	Status string
+}
+
+// server wires the cache, metrics and scrape parameters together for the
+// serve subcommand's HTTP handlers.
+type server struct {
+	cache   *snapshotCache
+	metrics *serveMetrics
+
+	client      *fischertermine.Client
+	concurrency int
+	icsDuration time.Duration
+	geocoder    geocode.Geocoder
+	near        *geocode.Coordinates
+	radiusKm    float64
+	sortBy      string
+}
+
+// runScrapeLoop scrapes once immediately, then again on every tick of
+// interval, updating the cache and metrics after each run.
+func (s *server) runScrapeLoop(ctx context.Context, interval time.Duration) {
+	for {
+		start := time.Now()
+		exams, err := scrape(ctx, s.client, s.concurrency, s.geocoder, s.near, s.radiusKm, s.sortBy)
+		d := time.Since(start)
+		if err != nil {
+			log.Printf("Error scraping exams: %v", err)
+			s.metrics.recordFailure(d)
+		} else {
+			s.cache.update(exams, s.icsDuration, time.Now())
+			s.metrics.recordSuccess(len(exams), d)
+		}
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// serveCached writes body with Last-Modified/ETag/Cache-Control headers,
+// answering with 304 if the client's cache is already fresh.
+func serveCached(w http.ResponseWriter, r *http.Request, contentType string, body []byte, etag string, lastModified time.Time) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", `"`+etag+`"`)
+	w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d", int(cacheMaxAge.Seconds())))
+
+	if match := r.Header.Get("If-None-Match"); match != "" && match == `"`+etag+`"` {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Write(body)
+}
+
+func (s *server) handleExamsJSON(w http.ResponseWriter, r *http.Request) {
+	_, jsonBytes, _, etag, lastModified, ready := s.cache.snapshot()
+	if !ready {
+		http.Error(w, "no scrape completed yet", http.StatusServiceUnavailable)
+		return
+	}
+	serveCached(w, r, "application/json", jsonBytes, etag, lastModified)
+}
+
+func (s *server) handleExamsICS(w http.ResponseWriter, r *http.Request) {
+	_, _, icsBytes, etag, lastModified, ready := s.cache.snapshot()
+	if !ready {
+		http.Error(w, "no scrape completed yet", http.StatusServiceUnavailable)
+		return
+	}
+	serveCached(w, r, "text/calendar; charset=utf-8", icsBytes, etag, lastModified)
+}
+
+func (s *server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	exams, _, _, _, _, ready := s.cache.snapshot()
+	if !ready {
+		http.Error(w, "no scrape completed yet", http.StatusServiceUnavailable)
+		return
+	}
+
+	page := indexPage{
+		Total:  len(exams),
+		Region: r.URL.Query().Get("region"),
+		City:   r.URL.Query().Get("city"),
+		Status: r.URL.Query().Get("status"),
+	}
+	for _, exam := range exams {
+		if page.Region != "" && !strings.EqualFold(exam.Region, page.Region) {
+			continue
+		}
+		if page.City != "" && !strings.EqualFold(exam.City, page.City) {
+			continue
+		}
+		if page.Status != "" && !strings.Contains(strings.ToLower(exam.Status+" "+exam.DetailStatus), strings.ToLower(page.Status)) {
+			continue
+		}
+		page.Exams = append(page.Exams, exam)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := indexTemplate.Execute(w, page); err != nil {
+		log.Printf("Error rendering index page: %v", err)
+	}
+}
+
+func (s *server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	if _, _, _, _, _, ready := s.cache.snapshot(); !ready {
+		http.Error(w, "no scrape completed yet", http.StatusServiceUnavailable)
+		return
+	}
+	fmt.Fprintln(w, "ok")
+}
+
+func (s *server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprint(w, s.metrics.render())
+}
+
+// runServe implements the serve subcommand: it runs the scraper on a
+// background timer and exposes the latest snapshot over HTTP until killed.
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", ":8080", "address to listen on")
+	interval := fs.Duration("interval", 15*time.Minute, "how often to re-run the scraper")
+	concurrency := fs.Int("concurrency", 20, "number of exam detail pages to fetch concurrently")
+	icsDuration := fs.Duration("ics-duration", 90*time.Minute, "VEVENT duration to assume for /exams.ics")
+	doGeocode := fs.Bool("geocode", false, "resolve each exam's address to latitude/longitude")
+	geocoderBackend := fs.String("geocoder", "nominatim", "geocoding backend to use: nominatim or photon")
+	geocodeCachePath := fs.String("geocode-cache", "geocode.cache.json", "path to the on-disk geocoding cache")
+	near := fs.String("near", "", "lat,lon to measure exam distance from; implies --geocode")
+	radiusKm := fs.Float64("radius-km", 0, "with --near, drop exams farther than this many km away")
+	sortBy := fs.String("sort-by", "", "additional sort applied after the default date/location/status sort: distance (requires --near)")
+	fs.Parse(args)
+
+	var nearCoord *geocode.Coordinates
+	if *near != "" {
+		coord, err := parseCoordinates(*near)
+		if err != nil {
+			log.Fatalf("Error parsing --near: %v", err)
+		}
+		nearCoord = &coord
+		*doGeocode = true
+	}
+
+	var geo geocode.Geocoder
+	if *doGeocode {
+		g, err := newGeocoder(*geocoderBackend, *geocodeCachePath)
+		if err != nil {
+			log.Fatalf("Error setting up geocoder: %v", err)
+		}
+		geo = g
+	}
+
+	s := &server{
+		cache:       &snapshotCache{},
+		metrics:     &serveMetrics{},
+		client:      fischertermine.NewClient(),
+		concurrency: *concurrency,
+		icsDuration: *icsDuration,
+		geocoder:    geo,
+		near:        nearCoord,
+		radiusKm:    *radiusKm,
+		sortBy:      *sortBy,
+	}
+
+	ctx := context.Background()
+	go s.runScrapeLoop(ctx, *interval)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handleIndex)
+	mux.HandleFunc("/exams.json", s.handleExamsJSON)
+	mux.HandleFunc("/exams.ics", s.handleExamsICS)
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	mux.HandleFunc("/metrics", s.handleMetrics)
+
+	log.Printf("Listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
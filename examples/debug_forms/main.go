@@ -1,38 +1,38 @@
+// Command debug_forms prints every <form> found on the exam search page,
+// useful when the booking system's markup changes and scraping breaks.
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
-	"net/http/cookiejar"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/tkschmidt/fischertermine/pkg/fischertermine"
 )
 
 func main() {
-	baseURL := "https://fischerpruefung-online.bayern.de/fprApp/"
-	listURL := "https://fischerpruefung-online.bayern.de/fprApp/verwaltung/Pruefungssuche?execution=e9s1"
+	client := fischertermine.NewClient()
+	ctx := context.Background()
 
-	jar, _ := cookiejar.New(nil)
-	client := &http.Client{Jar: jar}
-
-	// Visit base page
-	req, _ := http.NewRequest("GET", baseURL, nil)
-	req.Header.Set("User-Agent", "Mozilla/5.0")
-	client.Do(req)
+	req, err := http.NewRequestWithContext(ctx, "GET", client.ListURL, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+	req.Header.Set("User-Agent", client.UserAgent)
 
-	// Get list page
-	req, _ = http.NewRequest("GET", listURL, nil)
-	req.Header.Set("User-Agent", "Mozilla/5.0")
 	resp, err := client.Do(req)
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer resp.Body.Close()
 
-	doc, _ := goquery.NewDocumentFromReader(resp.Body)
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	// Find all forms
 	doc.Find("form").Each(func(i int, form *goquery.Selection) {
 		action, _ := form.Attr("action")
 		method, _ := form.Attr("method")
@@ -45,7 +45,6 @@ func main() {
 		fmt.Printf("Method: %s\n", method)
 		fmt.Printf("Enctype: %s\n", enctype)
 
-		// Count submit buttons in this form
 		submitCount := 0
 		form.Find("input[type=submit]").Each(func(j int, s *goquery.Selection) {
 			submitCount++
@@ -0,0 +1,92 @@
+package geocode
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache wraps a Geocoder with an on-disk, address-keyed cache and a minimum
+// delay between lookups, so that repeated runs don't re-geocode the same
+// address and callers don't exceed a backend's rate policy (e.g.
+// Nominatim's 1 request/second limit).
+type Cache struct {
+	inner       Geocoder
+	path        string
+	minInterval time.Duration
+
+	mu       sync.Mutex
+	entries  map[string]Coordinates
+	lastCall time.Time
+}
+
+// NewCache loads any existing cache file at path (a missing file is not an
+// error) and returns a Cache that persists new lookups there.
+func NewCache(inner Geocoder, path string, minInterval time.Duration) (*Cache, error) {
+	c := &Cache{inner: inner, path: path, minInterval: minInterval, entries: map[string]Coordinates{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("geocode: reading cache %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &c.entries); err != nil {
+		return nil, fmt.Errorf("geocode: decoding cache %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// normalizeAddress folds an address to a stable cache key.
+func normalizeAddress(address string) string {
+	return strings.Join(strings.Fields(strings.ToLower(address)), " ")
+}
+
+// Geocode returns the cached coordinates for address if present; otherwise
+// it waits out any remaining minInterval, calls the inner Geocoder, and
+// persists the result before returning it.
+func (c *Cache) Geocode(ctx context.Context, address string) (lat, lon float64, err error) {
+	key := normalizeAddress(address)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if coord, ok := c.entries[key]; ok {
+		return coord.Lat, coord.Lon, nil
+	}
+
+	if wait := c.minInterval - time.Since(c.lastCall); wait > 0 {
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return 0, 0, ctx.Err()
+		}
+	}
+
+	lat, lon, err = c.inner.Geocode(ctx, address)
+	c.lastCall = time.Now()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	c.entries[key] = Coordinates{Lat: lat, Lon: lon}
+	if err := c.save(); err != nil {
+		return lat, lon, fmt.Errorf("geocode: caching result for %q: %w", address, err)
+	}
+	return lat, lon, nil
+}
+
+// save must be called with c.mu held.
+func (c *Cache) save() error {
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, data, 0o644)
+}
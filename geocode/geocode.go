@@ -0,0 +1,19 @@
+// Package geocode resolves postal addresses to coordinates, and measures
+// distances between them.
+package geocode
+
+import "context"
+
+// Geocoder resolves a free-form address to WGS84 coordinates.
+type Geocoder interface {
+	Geocode(ctx context.Context, address string) (lat, lon float64, err error)
+}
+
+// Coordinates is a WGS84 latitude/longitude pair.
+type Coordinates struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// earthRadiusKm is the mean Earth radius used by HaversineKm.
+const earthRadiusKm = 6371.0088
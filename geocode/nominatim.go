@@ -0,0 +1,82 @@
+package geocode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const defaultNominatimEndpoint = "https://nominatim.openstreetmap.org/search"
+
+// NominatimGeocoder resolves addresses via OpenStreetMap's public Nominatim
+// search endpoint. Nominatim's usage policy requires a descriptive
+// User-Agent identifying the application, and limits callers to one
+// request per second; this type only makes the request, so callers should
+// wrap it in a Cache to satisfy the rate limit.
+type NominatimGeocoder struct {
+	Client    *http.Client
+	UserAgent string
+	Endpoint  string
+}
+
+// NewNominatimGeocoder returns a NominatimGeocoder identifying itself with
+// userAgent, as required by Nominatim's usage policy.
+func NewNominatimGeocoder(userAgent string) *NominatimGeocoder {
+	return &NominatimGeocoder{UserAgent: userAgent}
+}
+
+func (g *NominatimGeocoder) Geocode(ctx context.Context, address string) (lat, lon float64, err error) {
+	if g.UserAgent == "" {
+		return 0, 0, fmt.Errorf("geocode: nominatim requires a User-Agent identifying the application")
+	}
+
+	endpoint := g.Endpoint
+	if endpoint == "" {
+		endpoint = defaultNominatimEndpoint
+	}
+
+	q := url.Values{}
+	q.Set("q", address)
+	q.Set("format", "json")
+	q.Set("limit", "1")
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint+"?"+q.Encode(), nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("geocode: building nominatim request: %w", err)
+	}
+	req.Header.Set("User-Agent", g.UserAgent)
+
+	client := g.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("geocode: nominatim request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var results []struct {
+		Lat string `json:"lat"`
+		Lon string `json:"lon"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return 0, 0, fmt.Errorf("geocode: decoding nominatim response: %w", err)
+	}
+	if len(results) == 0 {
+		return 0, 0, fmt.Errorf("geocode: nominatim found no results for %q", address)
+	}
+
+	lat, err = strconv.ParseFloat(results[0].Lat, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("geocode: parsing nominatim latitude: %w", err)
+	}
+	lon, err = strconv.ParseFloat(results[0].Lon, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("geocode: parsing nominatim longitude: %w", err)
+	}
+	return lat, lon, nil
+}
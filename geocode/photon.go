@@ -0,0 +1,66 @@
+package geocode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const defaultPhotonEndpoint = "https://photon.komoot.io/api/"
+
+// PhotonGeocoder resolves addresses via Komoot's public Photon search
+// endpoint, an alternative to NominatimGeocoder with its own quota.
+type PhotonGeocoder struct {
+	Client   *http.Client
+	Endpoint string
+}
+
+// NewPhotonGeocoder returns a PhotonGeocoder using the public Photon API.
+func NewPhotonGeocoder() *PhotonGeocoder {
+	return &PhotonGeocoder{}
+}
+
+func (g *PhotonGeocoder) Geocode(ctx context.Context, address string) (lat, lon float64, err error) {
+	endpoint := g.Endpoint
+	if endpoint == "" {
+		endpoint = defaultPhotonEndpoint
+	}
+
+	q := url.Values{}
+	q.Set("q", address)
+	q.Set("limit", "1")
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint+"?"+q.Encode(), nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("geocode: building photon request: %w", err)
+	}
+
+	client := g.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("geocode: photon request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Features []struct {
+			Geometry struct {
+				Coordinates []float64 `json:"coordinates"` // [lon, lat]
+			} `json:"geometry"`
+		} `json:"features"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return 0, 0, fmt.Errorf("geocode: decoding photon response: %w", err)
+	}
+	if len(body.Features) == 0 || len(body.Features[0].Geometry.Coordinates) < 2 {
+		return 0, 0, fmt.Errorf("geocode: photon found no results for %q", address)
+	}
+
+	coords := body.Features[0].Geometry.Coordinates
+	return coords[1], coords[0], nil
+}
@@ -0,0 +1,60 @@
+// Package notify delivers exam availability Change events to pluggable
+// sinks (Telegram, email, generic webhooks).
+package notify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tkschmidt/fischertermine/store"
+)
+
+// Event is the payload handed to a Sink for a single detected change.
+type Event struct {
+	EventType store.ChangeType    `json:"event_type"`
+	UID       string              `json:"uid"`
+	Before    *store.ExamSnapshot `json:"before,omitempty"`
+	After     store.ExamSnapshot  `json:"after"`
+}
+
+// Sink delivers a batch of events somewhere (chat, inbox, HTTP endpoint).
+// Implementations should treat events as best-effort: a failed send should
+// not prevent the next scrape from running.
+type Sink interface {
+	Notify(ctx context.Context, events []Event) error
+}
+
+// EventsFromChanges adapts store.Change values into notify.Events.
+func EventsFromChanges(changes []store.Change) []Event {
+	events := make([]Event, 0, len(changes))
+	for _, c := range changes {
+		events = append(events, Event{
+			EventType: c.Type,
+			UID:       c.UID,
+			Before:    c.Before,
+			After:     c.After,
+		})
+	}
+	return events
+}
+
+// summary renders a short human-readable line for an event, shared by the
+// Telegram and email sinks.
+func summary(e Event) string {
+	loc := e.After.Location
+	when := e.After.DateTime
+	switch e.EventType {
+	case store.ChangeNew:
+		return fmt.Sprintf("Neuer Termin: %s in %s", when, loc)
+	case store.ChangeBecameFree:
+		return fmt.Sprintf("Wieder frei: %s in %s", when, loc)
+	case store.ChangeBecameFull:
+		return fmt.Sprintf("Jetzt belegt: %s in %s", when, loc)
+	case store.ChangeCancelled:
+		return fmt.Sprintf("Abgesagt: %s in %s", when, loc)
+	case store.ChangeParticipants:
+		return fmt.Sprintf("Teilnehmerzahl geändert: %s in %s (jetzt %s)", when, loc, e.After.CurrentParticipants)
+	default:
+		return fmt.Sprintf("%s: %s in %s", e.EventType, when, loc)
+	}
+}
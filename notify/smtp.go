@@ -0,0 +1,49 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+)
+
+// SMTPSink delivers events as a single plain-text email per batch, sent
+// through a standard SMTP relay.
+type SMTPSink struct {
+	Addr string // host:port of the SMTP server
+	Auth smtp.Auth
+	From string
+	To   []string
+}
+
+// NewSMTPSink returns an SMTPSink authenticating with PLAIN auth.
+func NewSMTPSink(addr, username, password, host, from string, to []string) *SMTPSink {
+	return &SMTPSink{
+		Addr: addr,
+		Auth: smtp.PlainAuth("", username, password, host),
+		From: from,
+		To:   to,
+	}
+}
+
+// Notify ignores ctx, as net/smtp has no context-aware API; it sends one
+// email summarizing all events in the batch.
+func (s *SMTPSink) Notify(ctx context.Context, events []Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Subject: fischertermine: %d Änderung(en)\r\n", len(events))
+	fmt.Fprintf(&body, "From: %s\r\n", s.From)
+	fmt.Fprintf(&body, "To: %s\r\n\r\n", strings.Join(s.To, ", "))
+	for _, e := range events {
+		body.WriteString(summary(e))
+		body.WriteString("\r\n")
+	}
+
+	if err := smtp.SendMail(s.Addr, s.Auth, s.From, s.To, []byte(body.String())); err != nil {
+		return fmt.Errorf("notify: sending email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// TelegramSink delivers events as chat messages via the Telegram Bot API.
+type TelegramSink struct {
+	Token  string
+	ChatID string
+	Client *http.Client
+}
+
+// NewTelegramSink returns a TelegramSink for the bot identified by token,
+// posting to chatID, using http.DefaultClient.
+func NewTelegramSink(token, chatID string) *TelegramSink {
+	return &TelegramSink{Token: token, ChatID: chatID, Client: http.DefaultClient}
+}
+
+// Notify sends one sendMessage call per event.
+func (t *TelegramSink) Notify(ctx context.Context, events []Event) error {
+	client := t.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	api := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", t.Token)
+
+	for _, e := range events {
+		form := url.Values{}
+		form.Set("chat_id", t.ChatID)
+		form.Set("text", summary(e))
+
+		req, err := http.NewRequestWithContext(ctx, "POST", api, nil)
+		if err != nil {
+			return fmt.Errorf("notify: building telegram request: %w", err)
+		}
+		req.URL.RawQuery = form.Encode()
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("notify: sending telegram message for event %s: %w", e.UID, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("notify: telegram API returned status %d for event %s", resp.StatusCode, e.UID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// WebhookSink POSTs each event as a JSON {event_type, before, after} payload
+// to a configured URL.
+type WebhookSink struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewWebhookSink returns a WebhookSink posting to url using http.DefaultClient.
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{URL: url, Client: http.DefaultClient}
+}
+
+// Notify POSTs each event individually so a receiver can process them as a
+// stream rather than needing to unwrap a batch.
+func (w *WebhookSink) Notify(ctx context.Context, events []Event) error {
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	for _, e := range events {
+		body, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("notify: encoding webhook payload: %w", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", w.URL, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("notify: building webhook request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("notify: posting webhook event %s: %w", e.UID, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("notify: webhook returned status %d for event %s", resp.StatusCode, e.UID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package fischertermine
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"sync"
+	"time"
+)
+
+const (
+	defaultOrigin    = "https://fischerpruefung-online.bayern.de"
+	defaultBaseURL   = defaultOrigin + "/fprApp/"
+	defaultListPath  = "verwaltung/Pruefungssuche?execution=e9s1"
+	defaultUserAgent = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36"
+	maxRedirects     = 20
+
+	// defaultRateLimitInterval and defaultRateLimitBurst bound how fast
+	// ListAll's workers can hit the detail-page endpoint, regardless of how
+	// many workers are configured.
+	defaultRateLimitInterval = 150 * time.Millisecond
+	defaultRateLimitBurst    = 5
+)
+
+// Client talks to the Bavarian Fischerprüfung booking system. It embeds
+// *http.Client so callers can tweak Timeout, Transport, etc. directly.
+//
+// A Client holds one shared Session, reused across all detail-page
+// fetches instead of opening a new session per exam; see FetchDetails.
+type Client struct {
+	*http.Client
+
+	// BaseURL is the application's landing page, visited once per session
+	// to pick up the initial session cookie.
+	BaseURL string
+	// ListURL is the exam search page that lists all open appointments.
+	ListURL string
+	// Origin is the scheme+host that relative form actions resolve against.
+	Origin string
+	// UserAgent is sent with every request; the booking system has been
+	// observed to reject requests without one.
+	UserAgent string
+
+	limiter *rateLimiter
+
+	// mu guards session: reading the current Session and swapping in a
+	// freshly opened one. It is only ever held for that bookkeeping, never
+	// across a detail-page network round-trip, so concurrent FetchDetails
+	// calls can have many submissions in flight at once; an occasional
+	// stale-flow bounce from racing the shared ViewState is expected and
+	// handled by FetchDetails' refresh-and-retry.
+	mu      sync.Mutex
+	session *Session
+}
+
+// NewClient returns a Client with its own cookie jar, ready to scrape.
+func NewClient() *Client {
+	jar, _ := cookiejar.New(nil)
+	return &Client{
+		Client: &http.Client{
+			Jar:           jar,
+			CheckRedirect: limitRedirects,
+		},
+		BaseURL:   defaultBaseURL,
+		ListURL:   defaultBaseURL + defaultListPath,
+		Origin:    defaultOrigin,
+		UserAgent: defaultUserAgent,
+		limiter:   newRateLimiter(defaultRateLimitInterval, defaultRateLimitBurst),
+	}
+}
+
+// sharedSession returns the Client's current Session, opening one if none
+// has been established yet.
+func (c *Client) sharedSession(ctx context.Context) (*Session, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.session == nil {
+		session, err := c.newSession(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.session = session
+	}
+	return c.session, nil
+}
+
+// refreshSession discards the Client's current Session and opens a new one,
+// for use after a detail fetch reports a stale flow. observed is the
+// Session the caller submitted against; if another goroutine has already
+// refreshed past it by the time refreshSession acquires mu, that newer
+// Session is returned instead of opening a redundant one, so N concurrent
+// callers hitting the same stale flow trigger a single refresh rather than
+// a thundering herd of them.
+func (c *Client) refreshSession(ctx context.Context, observed *Session) (*Session, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.session != observed {
+		return c.session, nil
+	}
+	session, err := c.newSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.session = session
+	return session, nil
+}
+
+func limitRedirects(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("too many redirects")
+	}
+	return nil
+}
+
+// newRequestCtx builds a GET/POST request bound to ctx, with the headers
+// this site expects.
+func (c *Client) newRequestCtx(ctx context.Context, method, url string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.UserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+	return req, nil
+}
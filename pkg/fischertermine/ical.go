@@ -0,0 +1,156 @@
+package fischertermine
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// berlinLocation is the timezone all exam appointments are published in.
+var berlinLocation = func() *time.Location {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}()
+
+// icsDateTimeLayout formats a local time for use after a TZID-qualified
+// DTSTART/DTEND property, per RFC 5545 ("floating" local time, no trailing Z).
+const icsDateTimeLayout = "20060102T150405"
+
+// examStartTime resolves the best-known start time for an exam, preferring
+// the detail page's ExamDate/ExamStartTime over the coarser list DateTime.
+func examStartTime(exam ExamAppointment) (time.Time, bool) {
+	if exam.ExamDate != "" && exam.ExamStartTime != "" {
+		if t, err := time.ParseInLocation("02.01.2006 15:04", exam.ExamDate+" "+exam.ExamStartTime, berlinLocation); err == nil {
+			return t, true
+		}
+	}
+	if exam.DateTime != "" {
+		if t, err := time.ParseInLocation("02.01.2006, 15:04", exam.DateTime, berlinLocation); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// UID derives a stable identifier for an exam so that repeated exports or
+// scrapes of the same appointment produce the same key, letting calendar
+// clients update rather than duplicate events and letting store.Snapshot
+// match exams across runs.
+func UID(exam ExamAppointment) string {
+	sum := sha1.Sum([]byte(exam.DateTime + "|" + exam.Location))
+	return fmt.Sprintf("%x@fischerpruefung-online.bayern.de", sum)
+}
+
+// examIsCancelled reports whether the exam's status indicates it is full,
+// cancelled or otherwise no longer taking participants.
+func examIsCancelled(exam ExamAppointment) bool {
+	status := exam.DetailStatus + " " + exam.Status
+	return IsCancelledStatus(status) || IsFullStatus(status)
+}
+
+// icsEscape escapes text per RFC 5545 section 3.3.11.
+func icsEscape(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	s = strings.ReplaceAll(s, ";", "\\;")
+	s = strings.ReplaceAll(s, ",", "\\,")
+	s = strings.ReplaceAll(s, "\n", "\\n")
+	return s
+}
+
+// icsFoldLine wraps a content line at 75 octets as required by RFC 5545,
+// continuing folded lines with a single leading space. The cut is walked
+// back to the nearest rune boundary so a multi-byte UTF-8 character (this
+// package deals with plenty of German umlauts) never gets split across the
+// fold.
+func icsFoldLine(line string) string {
+	const maxLen = 75
+	if len(line) <= maxLen {
+		return line
+	}
+	var b strings.Builder
+	for len(line) > maxLen {
+		cut := maxLen
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+	}
+	b.WriteString(line)
+	return b.String()
+}
+
+// icsLocation builds the LOCATION value for an exam from its address fields.
+func icsLocation(exam ExamAppointment) string {
+	var parts []string
+	for _, p := range []string{exam.ExamVenue, exam.Room, strings.TrimSpace(exam.Street + " " + exam.HouseNumber), strings.TrimSpace(exam.PostalCode + " " + exam.City)} {
+		if p != "" && p != " " {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// BuildICS renders the exams as a single VCALENDAR stream. Exams whose start
+// time cannot be determined are skipped, since DTSTART is mandatory.
+func BuildICS(exams []ExamAppointment, defaultDuration time.Duration, generatedAt time.Time) string {
+	var b strings.Builder
+	b.WriteString("BEGIN:VCALENDAR\r\n")
+	b.WriteString("VERSION:2.0\r\n")
+	b.WriteString("PRODID:-//fischertermine//DE\r\n")
+	b.WriteString("CALSCALE:GREGORIAN\r\n")
+
+	for _, exam := range exams {
+		start, ok := examStartTime(exam)
+		if !ok {
+			continue
+		}
+		end := start.Add(defaultDuration)
+
+		summary := "Fischerprüfung " + exam.Location
+		if examIsCancelled(exam) {
+			summary = "ABGESAGT: " + summary
+		}
+
+		var desc []string
+		if exam.CurrentParticipants != "" || exam.MaxParticipants != "" {
+			desc = append(desc, fmt.Sprintf("Teilnehmer: %s/%s (min %s)", exam.CurrentParticipants, exam.MaxParticipants, exam.MinParticipants))
+		}
+		if exam.DetailStatus != "" {
+			desc = append(desc, "Status: "+exam.DetailStatus)
+		} else if exam.Status != "" {
+			desc = append(desc, "Status: "+exam.Status)
+		}
+
+		b.WriteString("BEGIN:VEVENT\r\n")
+		b.WriteString(icsFoldLine("UID:"+UID(exam)) + "\r\n")
+		b.WriteString(icsFoldLine("DTSTAMP:"+generatedAt.UTC().Format(icsDateTimeLayout)+"Z") + "\r\n")
+		b.WriteString(icsFoldLine("DTSTART;TZID=Europe/Berlin:"+start.Format(icsDateTimeLayout)) + "\r\n")
+		b.WriteString(icsFoldLine("DTEND;TZID=Europe/Berlin:"+end.Format(icsDateTimeLayout)) + "\r\n")
+		b.WriteString(icsFoldLine("SUMMARY:"+icsEscape(summary)) + "\r\n")
+		if loc := icsLocation(exam); loc != "" {
+			b.WriteString(icsFoldLine("LOCATION:"+icsEscape(loc)) + "\r\n")
+			if exam.Latitude != 0 || exam.Longitude != 0 {
+				b.WriteString(icsFoldLine(fmt.Sprintf("GEO:%f;%f", exam.Latitude, exam.Longitude)) + "\r\n")
+				b.WriteString(icsFoldLine(fmt.Sprintf("X-APPLE-STRUCTURED-LOCATION;VALUE=URI;X-ADDRESS=%s;X-APPLE-RADIUS=100;X-TITLE=%s:geo:%f,%f",
+					icsEscape(loc), icsEscape(loc), exam.Latitude, exam.Longitude)) + "\r\n")
+			}
+		}
+		if len(desc) > 0 {
+			b.WriteString(icsFoldLine("DESCRIPTION:"+icsEscape(strings.Join(desc, "\\n"))) + "\r\n")
+		}
+		if examIsCancelled(exam) {
+			b.WriteString("STATUS:CANCELLED\r\n")
+		}
+		b.WriteString("END:VEVENT\r\n")
+	}
+
+	b.WriteString("END:VCALENDAR\r\n")
+	return b.String()
+}
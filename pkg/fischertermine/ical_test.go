@@ -0,0 +1,71 @@
+package fischertermine
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// TestIcsFoldLineKeepsUTF8RuneBoundaries reproduces the bug directly: a
+// line built from umlaut-heavy German address text, long enough that the
+// naive 75-byte cut used to land in the middle of a multi-byte rune (e.g.
+// splitting "straße" into "stra\xc3" + "\x9fe"). The loop pads the line by
+// one extra byte at a time, sweeping the "ß" across every possible
+// alignment relative to the fixed 75-byte cut point, so it's guaranteed to
+// land the cut mid-rune at least once; every folded segment must stay
+// valid UTF-8 on its own regardless, and unfolding must reconstruct the
+// original line exactly.
+func TestIcsFoldLineKeepsUTF8RuneBoundaries(t *testing.T) {
+	for pad := 0; pad < 30; pad++ {
+		line := "LOCATION:Landratsamt Rosenheim, " + strings.Repeat(" ", pad) +
+			"Wittelsbacherstraße 53, 83022 Rosenheim, Oberbayern"
+		if len(line) <= 75 {
+			t.Fatalf("test line is only %d bytes, too short to require folding", len(line))
+		}
+
+		folded := icsFoldLine(line)
+
+		var rebuilt strings.Builder
+		for _, seg := range strings.Split(folded, "\r\n ") {
+			if !utf8.ValidString(seg) {
+				t.Errorf("pad=%d: fold produced an invalid UTF-8 segment: %q", pad, seg)
+			}
+			if len(seg) > 75 {
+				t.Errorf("pad=%d: segment exceeds 75 octets: %d bytes (%q)", pad, len(seg), seg)
+			}
+			rebuilt.WriteString(seg)
+		}
+		if got := rebuilt.String(); got != line {
+			t.Errorf("pad=%d: folding/unfolding round-trip mismatch:\n got  %q\n want %q", pad, got, line)
+		}
+	}
+}
+
+// TestBuildICSProducesValidUTF8 builds a full ICS document for an exam
+// whose address fields are long enough to force LOCATION folding, and
+// checks the rendered calendar is valid UTF-8 throughout.
+func TestBuildICSProducesValidUTF8(t *testing.T) {
+	exam := ExamAppointment{
+		DateTime:            "14.03.2026, 09:00",
+		Location:            "Rosenheim",
+		ExamVenue:           "Landratsamt Rosenheim, Sitzungssaal für Prüfungen",
+		Street:              "Wittelsbacherstraße",
+		HouseNumber:         "53",
+		PostalCode:          "83022",
+		City:                "Rosenheim",
+		CurrentParticipants: "12",
+		MaxParticipants:     "30",
+		MinParticipants:     "5",
+		DetailStatus:        "frei",
+	}
+
+	out := BuildICS([]ExamAppointment{exam}, 90*time.Minute, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	if !utf8.ValidString(out) {
+		t.Fatal("BuildICS produced invalid UTF-8")
+	}
+	if !strings.Contains(out, "BEGIN:VEVENT") {
+		t.Fatal("BuildICS did not render the exam as an event")
+	}
+}
@@ -0,0 +1,171 @@
+package fischertermine
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func isHeaderSeparatorRow(cells []string) bool {
+	for _, cell := range cells {
+		cellLower := strings.ToLower(cell)
+		cityCount := 0
+		cities := []string{"augsburg", "bamberg", "freising", "münchen", "nürnberg", "regensburg", "rosenheim", "traunstein"}
+		regions := []string{"oberbayern", "oberpfalz", "oberfranken", "mittelfranken", "schwaben"}
+
+		for _, city := range cities {
+			if strings.Contains(cellLower, city) {
+				cityCount++
+			}
+		}
+		for _, region := range regions {
+			if strings.Contains(cellLower, region) {
+				cityCount++
+			}
+		}
+
+		if cityCount > 2 {
+			return true
+		}
+
+		if strings.Contains(cellLower, "frei") && strings.Contains(cellLower, "belegt") {
+			return true
+		}
+	}
+	return false
+}
+
+func isValidExamRow(cells []string) bool {
+	if len(cells) < 3 {
+		return false
+	}
+	firstCell := strings.TrimSpace(cells[0])
+	hasDatePattern := strings.Contains(firstCell, ".") && strings.Contains(firstCell, ",")
+	hasTimePattern := strings.Contains(firstCell, ":")
+	return hasDatePattern && hasTimePattern
+}
+
+func parseDateTime(dateTimeStr string) time.Time {
+	layout := "02.01.2006, 15:04"
+	parsed, err := time.Parse(layout, dateTimeStr)
+	if err != nil {
+		return time.Time{}
+	}
+	return parsed
+}
+
+// SortExams orders exams by date/time, then location, then status, so
+// output is stable across runs.
+func SortExams(exams []ExamAppointment) {
+	sort.Slice(exams, func(i, j int) bool {
+		dateI := parseDateTime(exams[i].DateTime)
+		dateJ := parseDateTime(exams[j].DateTime)
+
+		if !dateI.Equal(dateJ) {
+			return dateI.Before(dateJ)
+		}
+
+		if exams[i].Location != exams[j].Location {
+			return exams[i].Location < exams[j].Location
+		}
+
+		return exams[i].Status < exams[j].Status
+	})
+}
+
+// decomposedUmlauts collapses a combining-diaeresis vowel sequence (NFD,
+// e.g. "a"+U+0308) to its precomposed (NFC) form, since the detail page is
+// not consistent about which one it serves.
+var decomposedUmlauts = strings.NewReplacer(
+	"a\u0308", "\u00e4", "o\u0308", "\u00f6", "u\u0308", "\u00fc",
+	"A\u0308", "\u00c4", "O\u0308", "\u00d6", "U\u0308", "\u00dc",
+)
+
+// normalizeLabel folds a detail-page label to a stable lookup key: umlauts
+// collapsed to their precomposed form, a stripped trailing colon, and
+// case-insensitive comparison.
+func normalizeLabel(label string) string {
+	label = decomposedUmlauts.Replace(label)
+	label = strings.TrimRight(strings.TrimSpace(label), ":")
+	return strings.ToLower(strings.TrimSpace(label))
+}
+
+// fieldSetters maps a normalized German detail-page label to the function
+// that applies its value to an exam. Adding a field the detail page exposes
+// is a matter of adding an entry here, not editing a switch.
+var fieldSetters = map[string]func(exam *ExamAppointment, value string){
+	normalizeLabel("Prüfungslokal"):       func(exam *ExamAppointment, value string) { exam.ExamVenue = value },
+	normalizeLabel("Raum"):                func(exam *ExamAppointment, value string) { exam.Room = value },
+	normalizeLabel("PLZ"):                 func(exam *ExamAppointment, value string) { exam.PostalCode = value },
+	normalizeLabel("Straße"):              func(exam *ExamAppointment, value string) { exam.Street = value },
+	normalizeLabel("Hausnummer"):          func(exam *ExamAppointment, value string) { exam.HouseNumber = value },
+	normalizeLabel("Prüfungstermin"):      func(exam *ExamAppointment, value string) { exam.ExamDate = value },
+	normalizeLabel("Prüfungsbeginn"):      func(exam *ExamAppointment, value string) { exam.ExamStartTime = value },
+	normalizeLabel("Kopfhörer"):           func(exam *ExamAppointment, value string) { exam.Headphones = value },
+	normalizeLabel("Behindertengerecht"):  func(exam *ExamAppointment, value string) { exam.WheelchairAccessible = value },
+	normalizeLabel("Min. Teilnehmer"):     func(exam *ExamAppointment, value string) { exam.MinParticipants = value },
+	normalizeLabel("Max. Teilnehmer"):     func(exam *ExamAppointment, value string) { exam.MaxParticipants = value },
+	normalizeLabel("Aktuelle Teilnehmer"): func(exam *ExamAppointment, value string) { exam.CurrentParticipants = value },
+	normalizeLabel("Status"):              func(exam *ExamAppointment, value string) { exam.DetailStatus = value },
+}
+
+// labelValuePattern matches a "Label: Value" pair on a single line, for
+// detail pages that lay a field out as plain text rather than table/dl
+// markup. It's deliberately permissive about the label charset (including
+// \p{M}, since the site isn't consistent about serving precomposed vs.
+// NFD-decomposed umlauts); labels that don't match an entry in fieldSetters
+// are silently ignored.
+var labelValuePattern = regexp.MustCompile(`^\s*([\p{L}\p{M}. ]+?)\s*:\s*(.+?)\s*$`)
+
+// parseDetailPage extracts detail information from the detail view and
+// applies it to exam. It first looks for label/value pairs in <dl>
+// definition lists (label.NextSibling) and two-column <table> rows
+// (adjacent-cell lookup), since that's how the site structures the detail
+// page, then falls back to a per-line regex scan for anything laid out as
+// plain "Label: Value" text.
+func parseDetailPage(doc *goquery.Document, exam *ExamAppointment) {
+	applied := map[string]bool{}
+
+	apply := func(label, value string) {
+		key := normalizeLabel(label)
+		setter, ok := fieldSetters[key]
+		if !ok || applied[key] {
+			return
+		}
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return
+		}
+		setter(exam, value)
+		applied[key] = true
+	}
+
+	doc.Find("dl").Each(func(_ int, dl *goquery.Selection) {
+		dl.Find("dt").Each(func(_ int, dt *goquery.Selection) {
+			dd := dt.Next()
+			if !dd.Is("dd") {
+				return
+			}
+			apply(dt.Text(), dd.Text())
+		})
+	})
+
+	doc.Find("table tr").Each(func(_ int, row *goquery.Selection) {
+		cells := row.Find("td, th")
+		if cells.Length() < 2 {
+			return
+		}
+		apply(cells.Eq(0).Text(), cells.Eq(1).Text())
+	})
+
+	if len(applied) < len(fieldSetters) {
+		for _, line := range strings.Split(doc.Text(), "\n") {
+			if m := labelValuePattern.FindStringSubmatch(line); m != nil {
+				apply(m[1], m[2])
+			}
+		}
+	}
+}
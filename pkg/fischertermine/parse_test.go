@@ -0,0 +1,101 @@
+package fischertermine
+
+import (
+	"os"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// TestParseDetailPage exercises parseDetailPage against saved HTML fixtures
+// covering the three markup shapes the real detail page has been observed
+// to use: <dl> definition lists, two-column <table> rows, and a fallback of
+// plain "Label: Value" text lines (the last fixture also uses NFD-decomposed
+// umlauts, which the site is not consistent about).
+func TestParseDetailPage(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		want ExamAppointment
+	}{
+		{
+			name: "dl definition list",
+			file: "testdata/detail_dl.html",
+			want: ExamAppointment{
+				ExamVenue:            "Landratsamt Rosenheim, Sitzungssaal 2",
+				Street:               "Wittelsbacherstraße",
+				HouseNumber:          "53",
+				PostalCode:           "83022",
+				Room:                 "Sitzungssaal 2",
+				ExamDate:             "14.03.2026",
+				ExamStartTime:        "09:00",
+				MinParticipants:      "5",
+				MaxParticipants:      "30",
+				CurrentParticipants:  "12",
+				Headphones:           "ja",
+				WheelchairAccessible: "ja",
+				DetailStatus:         "frei",
+			},
+		},
+		{
+			name: "two-column table rows",
+			file: "testdata/detail_table.html",
+			want: ExamAppointment{
+				ExamVenue:            "Gymnasium Bamberg, Aula",
+				Street:               "Luitpoldstraße",
+				HouseNumber:          "7",
+				PostalCode:           "96047",
+				Room:                 "Aula",
+				ExamDate:             "02.05.2026",
+				ExamStartTime:        "13:30",
+				MinParticipants:      "5",
+				MaxParticipants:      "25",
+				CurrentParticipants:  "25",
+				Headphones:           "nein",
+				WheelchairAccessible: "nein",
+				DetailStatus:         "Belegt",
+			},
+		},
+		{
+			name: "plain-text fallback with decomposed umlauts",
+			file: "testdata/detail_plaintext.html",
+			want: ExamAppointment{
+				ExamVenue:            "Gasthaus zur Post, Nebenzimmer",
+				Street:               "Hauptstraße",
+				HouseNumber:          "12",
+				PostalCode:           "92224",
+				Room:                 "Nebenzimmer",
+				ExamDate:             "21.06.2026",
+				ExamStartTime:        "08:30",
+				MinParticipants:      "5",
+				MaxParticipants:      "20",
+				CurrentParticipants:  "3",
+				Headphones:           "nein",
+				WheelchairAccessible: "ja",
+				DetailStatus:         "frei",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.file)
+			if err != nil {
+				t.Fatalf("opening fixture: %v", err)
+			}
+			defer f.Close()
+
+			doc, err := goquery.NewDocumentFromReader(f)
+			if err != nil {
+				t.Fatalf("parsing fixture: %v", err)
+			}
+
+			var exam ExamAppointment
+			parseDetailPage(doc, &exam)
+
+			if exam != tt.want {
+				t.Errorf("parseDetailPage(%s) = %+v, want %+v", tt.file, exam, tt.want)
+			}
+		})
+	}
+}
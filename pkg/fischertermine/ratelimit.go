@@ -0,0 +1,47 @@
+package fischertermine
+
+import (
+	"context"
+	"time"
+)
+
+// rateLimiter is a small token-bucket limiter: up to burst requests may go
+// out immediately, after which one token is added back every interval. It
+// exists so ListAll can raise its worker count without the combined request
+// rate overwhelming the booking system.
+type rateLimiter struct {
+	tokens chan struct{}
+}
+
+func newRateLimiter(interval time.Duration, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	rl := &rateLimiter{tokens: make(chan struct{}, burst)}
+	for i := 0; i < burst; i++ {
+		rl.tokens <- struct{}{}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			select {
+			case rl.tokens <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	return rl
+}
+
+// wait blocks until a token is available or ctx is done.
+func (rl *rateLimiter) wait(ctx context.Context) error {
+	select {
+	case <-rl.tokens:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,145 @@
+package fischertermine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ListExams fetches the search page and returns the exams listed there.
+// Only the list-page fields (DateTime, Location, City, Region, Status) are
+// populated; call FetchDetails to fill in the rest.
+func (c *Client) ListExams(ctx context.Context) ([]ExamAppointment, error) {
+	session, err := c.newSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var exams []ExamAppointment
+	session.doc.Find("table").Each(func(i int, table *goquery.Selection) {
+		if len(strings.TrimSpace(table.Text())) <= 50 {
+			return
+		}
+
+		table.Find("tr").Each(func(j int, row *goquery.Selection) {
+			cells := rowCells(row)
+			if len(cells) <= 2 || isHeaderSeparatorRow(cells) || !isValidExamRow(cells) {
+				return
+			}
+
+			exam := ExamAppointment{
+				DateTime: cells[0],
+				Location: cells[1],
+				City:     cells[2],
+				Region:   cells[3],
+			}
+			if len(cells) >= 5 {
+				exam.Status = cells[4]
+			}
+			exams = append(exams, exam)
+		})
+	})
+
+	return exams, nil
+}
+
+// FetchDetails fetches and parses the detail page for a single exam,
+// filling in its detail fields in place.
+//
+// It reuses the Client's shared Session rather than opening a new one per
+// exam, rate-limiting each submission so many concurrent callers don't
+// overwhelm the booking system. Submissions against that shared Session are
+// not serialized: many detail-page POSTs can be in flight at once, so
+// ListAll's concurrency actually overlaps network round-trips instead of
+// queuing them one at a time. That does mean concurrent callers can race
+// the same ViewState and get bounced back to the search page as a stale
+// flow; when that happens the session is refreshed once (a refresh already
+// in progress from another goroutine is reused rather than triggering a
+// second one) and the submission retried.
+func (c *Client) FetchDetails(ctx context.Context, exam *ExamAppointment) error {
+	session, err := c.sharedSession(ctx)
+	if err != nil {
+		return fmt.Errorf("opening session for %s at %s: %w", exam.DateTime, exam.Location, err)
+	}
+
+	doc, stale, err := c.trySubmitDetail(ctx, session, *exam)
+	if err != nil {
+		return err
+	}
+	if stale {
+		session, err = c.refreshSession(ctx, session)
+		if err != nil {
+			return fmt.Errorf("refreshing stale session for %s at %s: %w", exam.DateTime, exam.Location, err)
+		}
+		doc, stale, err = c.trySubmitDetail(ctx, session, *exam)
+		if err != nil {
+			return err
+		}
+		if stale {
+			return fmt.Errorf("flow still stale after session refresh for %s at %s", exam.DateTime, exam.Location)
+		}
+	}
+
+	parseDetailPage(doc, exam)
+	return nil
+}
+
+// trySubmitDetail rate-limits and submits one detail-page request, reporting
+// whether the response looks like a stale-flow bounce back to the search
+// page rather than an error worth surfacing immediately.
+func (c *Client) trySubmitDetail(ctx context.Context, session *Session, exam ExamAppointment) (doc *goquery.Document, stale bool, err error) {
+	buttonName := session.buttonFor(exam)
+	if buttonName == "" {
+		return nil, true, nil
+	}
+
+	if err := c.limiter.wait(ctx); err != nil {
+		return nil, false, err
+	}
+
+	doc, finalURL, err := c.submitDetailForm(ctx, session, buttonName)
+	if err != nil {
+		return nil, false, fmt.Errorf("fetching detail page for %s at %s: %w", exam.DateTime, exam.Location, err)
+	}
+	if strings.Contains(finalURL, "Pruefungssuche") {
+		return nil, true, nil
+	}
+	return doc, false, nil
+}
+
+// ListAll lists all exams and fetches their details concurrently, using up
+// to concurrency workers. An exam whose details fail to fetch is still
+// returned, with only its list-page fields populated.
+func (c *Client) ListAll(ctx context.Context, concurrency int) ([]ExamAppointment, error) {
+	exams, err := c.ListExams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ExamAppointment, len(exams))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, exam := range exams {
+		wg.Add(1)
+		go func(index int, exam ExamAppointment) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			if err := c.FetchDetails(ctx, &exam); err != nil {
+				result[index] = exam
+				return
+			}
+			result[index] = exam
+		}(i, exam)
+	}
+
+	wg.Wait()
+	SortExams(result)
+	return result, nil
+}
@@ -0,0 +1,169 @@
+package fischertermine
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// detailPageHTML is the detail page returned for any accepted submission.
+const detailPageHTML = `<html><body><dl><dt>Status</dt><dd>frei</dd></dl></body></html>`
+
+// buildListPage renders a minimal stand-in for the real search page: one
+// form carrying the given execution token and ViewState, and one row per
+// exam with its own submit button.
+func buildListPage(action, viewState string, exams []ExamAppointment) string {
+	var rows strings.Builder
+	for i, exam := range exams {
+		fmt.Fprintf(&rows, "<tr><td>%s</td><td>%s</td><td>Teststadt</td><td>Testregion</td><td>frei</td>"+
+			"<td><input type=\"submit\" class=\"select\" name=\"btn%d\"></td></tr>\n", exam.DateTime, exam.Location, i)
+	}
+	return fmt.Sprintf(`<html><body>
+<form id="pruefungsterminSearch" action="%s">
+<input type="hidden" name="javax.faces.ViewState" value="%s">
+<table>
+%s
+</table>
+</form>
+</body></html>`, action, viewState, rows.String())
+}
+
+// TestFetchDetailsOverlapsNetworkRequests verifies that concurrent
+// FetchDetails calls actually overlap their detail-page POSTs instead of
+// being serialized behind a client-side lock: with no staleness in play,
+// fetching N independent exams concurrently against a server with a fixed
+// per-request latency should take roughly one request's worth of
+// wall-clock time, not N of them.
+func TestFetchDetailsOverlapsNetworkRequests(t *testing.T) {
+	const (
+		// workers is kept at the rate limiter's burst size so none of them
+		// have to wait on a refill; otherwise the limiter's own pacing
+		// would be indistinguishable from the serialization this test
+		// guards against.
+		workers = defaultRateLimitBurst
+		delay   = 40 * time.Millisecond
+	)
+
+	exams := make([]ExamAppointment, workers)
+	for i := range exams {
+		exams[i] = ExamAppointment{DateTime: fmt.Sprintf("0%d.01.2026, 10:00", i+1), Location: "Testort"}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/verwaltung/Pruefungssuche", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, buildListPage("/verwaltung/Pruefungstermin?execution=e1s1", "view-1", exams))
+	})
+	mux.HandleFunc("/verwaltung/Pruefungstermin", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		fmt.Fprint(w, detailPageHTML)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient()
+	client.BaseURL = server.URL + "/"
+	client.ListURL = server.URL + "/verwaltung/Pruefungssuche?execution=e9s1"
+	client.Origin = server.URL
+
+	var wg sync.WaitGroup
+	errs := make([]error, workers)
+	start := time.Now()
+	for i := range exams {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = client.FetchDetails(context.Background(), &exams[i])
+		}(i)
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("worker %d: FetchDetails returned error: %v", i, err)
+		}
+	}
+
+	if elapsed >= workers*delay {
+		t.Errorf("FetchDetails calls took %s for %d workers at %s each; detail submissions appear serialized, not overlapped", elapsed, workers, delay)
+	}
+}
+
+// TestFetchDetailsCoalescesConcurrentRefresh simulates every in-flight
+// submission racing a now-stale ViewState at once: the server only accepts
+// submissions carrying the session generation opened by the *second*
+// session, so every worker's first attempt bounces back to the search page
+// and must refresh. It asserts that all of them still recover via the
+// existing refresh-and-retry path, and that only one goroutine actually
+// opens the replacement session rather than every racing worker opening
+// its own.
+func TestFetchDetailsCoalescesConcurrentRefresh(t *testing.T) {
+	const workers = 6
+	exam := ExamAppointment{DateTime: "01.01.2026, 10:00", Location: "Testort"}
+
+	// baseGETs counts real session creations: newSession always visits
+	// BaseURL right before ListURL, whereas the list path also gets hit
+	// incidentally whenever a stale POST's 302 is auto-followed there, so
+	// only baseGETs isolates how many times a *new* session was actually
+	// opened.
+	var baseGETs, listGETs int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) { atomic.AddInt32(&baseGETs, 1) })
+	mux.HandleFunc("/verwaltung/Pruefungssuche", func(w http.ResponseWriter, r *http.Request) {
+		gen := atomic.AddInt32(&listGETs, 1) - 1 // first fetch is generation 0, every later one is 1+
+		viewState := "view-0"
+		if gen > 0 {
+			viewState = "view-1"
+		}
+		fmt.Fprint(w, buildListPage("/verwaltung/Pruefungstermin?execution=e1s1", viewState, []ExamAppointment{exam}))
+	})
+	mux.HandleFunc("/verwaltung/Pruefungstermin", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("parsing submitted form: %v", err)
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+		if r.FormValue("javax.faces.ViewState") != "view-1" {
+			http.Redirect(w, r, "/verwaltung/Pruefungssuche", http.StatusFound)
+			return
+		}
+		fmt.Fprint(w, detailPageHTML)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient()
+	client.BaseURL = server.URL + "/"
+	client.ListURL = server.URL + "/verwaltung/Pruefungssuche?execution=e9s1"
+	client.Origin = server.URL
+
+	var wg sync.WaitGroup
+	errs := make([]error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			e := exam
+			errs[i] = client.FetchDetails(context.Background(), &e)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("worker %d: FetchDetails returned error: %v", i, err)
+		}
+	}
+	if got := atomic.LoadInt32(&baseGETs); got != 2 {
+		t.Errorf("a new session was opened %d times, want 2 (one initial session, one coalesced refresh); concurrent stale detection triggered redundant refreshes", got)
+	}
+}
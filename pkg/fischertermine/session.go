@@ -0,0 +1,190 @@
+package fischertermine
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// executionPattern extracts the Spring-Webflow/JSF execution token (e.g.
+// "e4s2") that ties a form submission to a specific point in the server's
+// flow.
+var executionPattern = regexp.MustCompile(`execution=([^&]+)`)
+
+// FormState is the set of hidden fields the server expects echoed back on
+// the next submission, plus the flow token and action URL they were
+// discovered on. It is only valid for requests made against the same
+// session cookies it was extracted from.
+type FormState struct {
+	Action    string // form action, relative to Client.Origin
+	Execution string // execution=... flow token parsed from Action
+	ViewState string // javax.faces.ViewState hidden field, if the page uses one
+	Fields    map[string]string
+}
+
+// Session is a single GET of the exam search page: the parsed list document
+// together with the FormState the server expects detail requests to be
+// POSTed with.
+type Session struct {
+	doc   *goquery.Document
+	State FormState
+}
+
+// newSession visits the base page (to establish a session cookie) and then
+// the list page, returning the parsed list page and its FormState.
+func (c *Client) newSession(ctx context.Context) (*Session, error) {
+	baseReq, err := c.newRequestCtx(ctx, "GET", c.BaseURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building base request: %w", err)
+	}
+	if resp, err := c.Do(baseReq); err == nil {
+		resp.Body.Close()
+	}
+	// A failure here is not fatal: the base page only exists to establish a
+	// session cookie, and the list page request below still has a chance to
+	// succeed without it.
+
+	listReq, err := c.newRequestCtx(ctx, "GET", c.ListURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building list request: %w", err)
+	}
+	resp, err := c.Do(listReq)
+	if err != nil {
+		return nil, fmt.Errorf("fetching list page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("list page returned status %d", resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parsing list page: %w", err)
+	}
+
+	var formAction string
+	doc.Find("form#pruefungsterminSearch").Each(func(i int, form *goquery.Selection) {
+		if action, exists := form.Attr("action"); exists {
+			formAction = action
+		}
+	})
+	if formAction == "" {
+		return nil, fmt.Errorf("no form action found on list page")
+	}
+
+	return &Session{doc: doc, State: buildFormState(doc, formAction)}, nil
+}
+
+// buildFormState collects the hidden/text input fields of the search form,
+// plus the execution token and ViewState, so they can be echoed back on the
+// next POST without re-scanning the page.
+func buildFormState(doc *goquery.Document, formAction string) FormState {
+	state := FormState{
+		Action: formAction,
+		Fields: map[string]string{},
+	}
+	if m := executionPattern.FindStringSubmatch(formAction); m != nil {
+		state.Execution = m[1]
+	}
+
+	doc.Find("input").Each(func(i int, sel *goquery.Selection) {
+		name, nameExists := sel.Attr("name")
+		if !nameExists || name == "" {
+			return
+		}
+		inputType, _ := sel.Attr("type")
+		if inputType == "submit" || inputType == "image" {
+			return
+		}
+		if inputType == "checkbox" {
+			if _, checked := sel.Attr("checked"); !checked {
+				return
+			}
+		}
+		value, _ := sel.Attr("value")
+		state.Fields[name] = value
+	})
+
+	state.ViewState = state.Fields["javax.faces.ViewState"]
+	return state
+}
+
+// buttonFor finds the submit button name for a specific exam's row in the
+// session's list page.
+func (s *Session) buttonFor(targetExam ExamAppointment) string {
+	var buttonName string
+
+	s.doc.Find("table tr").Each(func(i int, row *goquery.Selection) {
+		if buttonName != "" {
+			return // Already found
+		}
+
+		cells := rowCells(row)
+		if len(cells) >= 3 && cells[0] == targetExam.DateTime && cells[1] == targetExam.Location {
+			row.Find("input[type=submit].select").Each(func(k int, btn *goquery.Selection) {
+				if name, exists := btn.Attr("name"); exists && buttonName == "" {
+					buttonName = name
+				}
+			})
+		}
+	})
+
+	return buttonName
+}
+
+// submitDetailForm POSTs the session's FormState with buttonName set,
+// returning the detail page it navigates to and the final URL the response
+// landed on. The final URL lets the caller notice a "stale flow" response:
+// the server silently redirects back to the search page, rather than
+// erroring, once a flow's execution token has expired.
+func (c *Client) submitDetailForm(ctx context.Context, s *Session, buttonName string) (doc *goquery.Document, finalURL string, err error) {
+	formData := url.Values{}
+	for name, value := range s.State.Fields {
+		formData.Set(name, value)
+	}
+	formData.Set(buttonName, "")
+
+	submitURL := c.Origin + s.State.Action
+	req, err := c.newRequestCtx(ctx, "POST", submitURL, strings.NewReader(formData.Encode()))
+	if err != nil {
+		return nil, "", fmt.Errorf("building detail request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("submitting detail form: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.Request != nil && resp.Request.URL != nil {
+		finalURL = resp.Request.URL.String()
+	}
+
+	doc, err = goquery.NewDocumentFromReader(resp.Body)
+	return doc, finalURL, err
+}
+
+func rowCells(row *goquery.Selection) []string {
+	var cells []string
+	row.Find("td, th").Each(func(k int, cell *goquery.Selection) {
+		cellText := strings.TrimSpace(cell.Text())
+		cellText = strings.ReplaceAll(cellText, "\t", " ")
+		cellText = strings.ReplaceAll(cellText, "\n", " ")
+		for strings.Contains(cellText, "  ") {
+			cellText = strings.ReplaceAll(cellText, "  ", " ")
+		}
+		cellText = strings.TrimSpace(cellText)
+
+		if cellText != "" && cellText != "-" {
+			cells = append(cells, cellText)
+		}
+	})
+	return cells
+}
@@ -0,0 +1,19 @@
+package fischertermine
+
+import "strings"
+
+// IsFullStatus reports whether a status string (list-page Status or
+// detail-page DetailStatus) indicates the exam is full/booked. It is
+// case-insensitive and matches on substring so callers don't need to
+// enumerate every casing the site happens to emit.
+func IsFullStatus(status string) bool {
+	status = strings.ToLower(status)
+	return strings.Contains(status, "belegt") || strings.Contains(status, "voll")
+}
+
+// IsCancelledStatus reports whether a status string indicates the exam was
+// cancelled/called off. See IsFullStatus for the matching rules.
+func IsCancelledStatus(status string) bool {
+	status = strings.ToLower(status)
+	return strings.Contains(status, "storniert") || strings.Contains(status, "abgesagt")
+}
@@ -0,0 +1,38 @@
+// Package fischertermine scrapes exam appointments from the Bavarian
+// Fischerprüfung ("fishing license exam") booking system.
+package fischertermine
+
+// ExamAppointment represents a fishing exam appointment with all details
+type ExamAppointment struct {
+	DateTime string `json:"date_time"`
+	Location string `json:"location"`
+	City     string `json:"city"`
+	Region   string `json:"region"`
+	Status   string `json:"status"`
+
+	// Detail fields (fetched from detail page)
+	ExamVenue            string `json:"exam_venue,omitempty"`
+	Room                 string `json:"room,omitempty"`
+	PostalCode           string `json:"postal_code,omitempty"`
+	Street               string `json:"street,omitempty"`
+	HouseNumber          string `json:"house_number,omitempty"`
+	ExamDate             string `json:"exam_date,omitempty"`
+	ExamStartTime        string `json:"exam_start_time,omitempty"`
+	Headphones           string `json:"headphones,omitempty"`
+	WheelchairAccessible string `json:"wheelchair_accessible,omitempty"`
+	MinParticipants      string `json:"min_participants,omitempty"`
+	MaxParticipants      string `json:"max_participants,omitempty"`
+	CurrentParticipants  string `json:"current_participants,omitempty"`
+	DetailStatus         string `json:"detail_status,omitempty"`
+
+	// Enrichment fields (filled in by an optional geocoding pass)
+	Latitude   float64 `json:"latitude,omitempty"`
+	Longitude  float64 `json:"longitude,omitempty"`
+	DistanceKm float64 `json:"distance_km,omitempty"`
+}
+
+// OutputData represents the JSON output structure
+type OutputData struct {
+	ExamAppointments []ExamAppointment `json:"exam_appointments"`
+	TotalCount       int               `json:"total_count"`
+}
@@ -0,0 +1,71 @@
+package store
+
+import "github.com/tkschmidt/fischertermine/pkg/fischertermine"
+
+// ChangeType identifies the kind of change detected between two snapshots of
+// the same exam.
+type ChangeType string
+
+const (
+	// ChangeNew means the exam did not appear in the previous snapshot.
+	ChangeNew ChangeType = "new"
+	// ChangeParticipants means CurrentParticipants changed.
+	ChangeParticipants ChangeType = "participants_changed"
+	// ChangeBecameFull means the exam transitioned from free to full/booked.
+	ChangeBecameFull ChangeType = "became_full"
+	// ChangeBecameFree means the exam transitioned from full/booked to free.
+	ChangeBecameFree ChangeType = "became_free"
+	// ChangeCancelled means the exam was marked cancelled.
+	ChangeCancelled ChangeType = "cancelled"
+)
+
+// Change describes a single detected difference for one exam UID.
+type Change struct {
+	UID    string
+	Type   ChangeType
+	Before *ExamSnapshot
+	After  ExamSnapshot
+}
+
+func isFull(s ExamSnapshot) bool {
+	status := s.DetailStatus
+	if status == "" {
+		status = s.Status
+	}
+	return fischertermine.IsFullStatus(status)
+}
+
+func isCancelled(s ExamSnapshot) bool {
+	status := s.DetailStatus
+	if status == "" {
+		status = s.Status
+	}
+	return fischertermine.IsCancelledStatus(status)
+}
+
+// Diff compares prev against next and returns one Change per exam that is
+// new, or whose participant count, fullness or cancellation status changed.
+func Diff(prev, next Snapshot) []Change {
+	var changes []Change
+
+	for uid, after := range next.Exams {
+		before, existed := prev.Exams[uid]
+		if !existed {
+			changes = append(changes, Change{UID: uid, Type: ChangeNew, After: after})
+			continue
+		}
+
+		switch {
+		case isCancelled(after) && !isCancelled(before):
+			changes = append(changes, Change{UID: uid, Type: ChangeCancelled, Before: &before, After: after})
+		case isFull(after) && !isFull(before):
+			changes = append(changes, Change{UID: uid, Type: ChangeBecameFull, Before: &before, After: after})
+		case !isFull(after) && isFull(before):
+			changes = append(changes, Change{UID: uid, Type: ChangeBecameFree, Before: &before, After: after})
+		case after.CurrentParticipants != before.CurrentParticipants:
+			changes = append(changes, Change{UID: uid, Type: ChangeParticipants, Before: &before, After: after})
+		}
+	}
+
+	return changes
+}
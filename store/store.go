@@ -0,0 +1,69 @@
+// Package store persists the last scraped exam snapshot to disk so that
+// successive runs can be diffed to detect availability changes.
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ExamSnapshot captures the fields needed to detect a meaningful change
+// between two scrapes of the same exam appointment.
+type ExamSnapshot struct {
+	DateTime            string `json:"date_time"`
+	Location            string `json:"location"`
+	Status              string `json:"status"`
+	DetailStatus        string `json:"detail_status"`
+	CurrentParticipants string `json:"current_participants"`
+}
+
+// Snapshot is the persisted view of the last scrape, keyed by a stable exam
+// UID so that appointments can be matched across runs.
+type Snapshot struct {
+	Exams map[string]ExamSnapshot `json:"exams"`
+}
+
+// Store persists Snapshots as JSON on disk at a fixed path.
+type Store struct {
+	path string
+}
+
+// New returns a Store backed by the JSON file at path.
+func New(path string) *Store {
+	return &Store{path: path}
+}
+
+// Load reads the last persisted snapshot. A missing file is not an error; it
+// yields an empty snapshot so the first run has nothing to diff against.
+func (s *Store) Load() (Snapshot, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Snapshot{Exams: map[string]ExamSnapshot{}}, nil
+	}
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("store: reading %s: %w", s.path, err)
+	}
+
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return Snapshot{}, fmt.Errorf("store: decoding %s: %w", s.path, err)
+	}
+	if snap.Exams == nil {
+		snap.Exams = map[string]ExamSnapshot{}
+	}
+	return snap, nil
+}
+
+// Save persists snap, overwriting any previous snapshot at path.
+func (s *Store) Save(snap Snapshot) error {
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return fmt.Errorf("store: encoding snapshot: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0o644); err != nil {
+		return fmt.Errorf("store: writing %s: %w", s.path, err)
+	}
+	return nil
+}